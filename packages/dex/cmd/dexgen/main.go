@@ -0,0 +1,28 @@
+// Command dexgen wires a Go package's dex:event-annotated functions
+// into a dex.Dex dispatcher by writing dex_handlers.go and def.json
+// next to the source it parses.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yethikrishna/levelcode/dex/gen"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package directory to parse")
+	flag.Parse()
+
+	pkg, err := gen.ParseDir(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dexgen:", err)
+		os.Exit(1)
+	}
+
+	if err := gen.Generate(pkg, *dir); err != nil {
+		fmt.Fprintln(os.Stderr, "dexgen:", err)
+		os.Exit(1)
+	}
+}
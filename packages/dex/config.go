@@ -0,0 +1,129 @@
+package dex
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultConfigPath = "./dex.yaml"
+	systemConfigPath  = "/etc/dex/dex.yaml"
+)
+
+// fileConfig mirrors the on-disk YAML schema for a Dex.
+type fileConfig struct {
+	Routes []routeConfig `yaml:"routes"`
+}
+
+// routeConfig is a single entry in fileConfig.Routes.
+type routeConfig struct {
+	Event     string `yaml:"event"`
+	Handler   string `yaml:"handler"`
+	Access    string `yaml:"access"`
+	Whitelist string `yaml:"whitelist"`
+}
+
+// NewDexFromConfig loads a Dex from the YAML file at path. If path is
+// empty, ./dex.yaml is tried first, followed by /etc/dex/dex.yaml.
+// Handlers referenced by the config must still be wired up with
+// RegisterHandler before events are dispatched.
+func NewDexFromConfig(path string) (*Dex, error) {
+	data, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg fileConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("dex: parsing config: %w", err)
+	}
+
+	d := New()
+	for _, rc := range cfg.Routes {
+		r, err := buildRoute(rc)
+		if err != nil {
+			return nil, err
+		}
+		d.routes = append(d.routes, r)
+	}
+	return d, nil
+}
+
+func readConfig(path string) ([]byte, error) {
+	if path != "" {
+		return os.ReadFile(path)
+	}
+	if data, err := os.ReadFile(defaultConfigPath); err == nil {
+		return data, nil
+	}
+	data, err := os.ReadFile(systemConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("dex: no config found at %q or %q: %w", defaultConfigPath, systemConfigPath, err)
+	}
+	return data, nil
+}
+
+func buildRoute(rc routeConfig) (route, error) {
+	g, err := glob.Compile(rc.Event)
+	if err != nil {
+		return route{}, fmt.Errorf("dex: route %q: invalid event glob: %w", rc.Event, err)
+	}
+
+	access, err := parseAccessLevel(rc.Access)
+	if err != nil {
+		return route{}, fmt.Errorf("dex: route %q: %w", rc.Event, err)
+	}
+
+	r := route{
+		pattern: g,
+		raw:     rc.Event,
+		handler: rc.Handler,
+		access:  access,
+	}
+
+	if rc.Whitelist != "" {
+		wl, err := loadWhitelist(rc.Whitelist)
+		if err != nil {
+			return route{}, fmt.Errorf("dex: route %q: %w", rc.Event, err)
+		}
+		r.whitelist = wl
+	}
+	return r, nil
+}
+
+func parseAccessLevel(s string) (AccessLevel, error) {
+	switch strings.ToLower(s) {
+	case "public":
+		return Public, nil
+	case "identified":
+		return Identified, nil
+	case "known":
+		return Known, nil
+	case "trusted":
+		return Trusted, nil
+	default:
+		return 0, fmt.Errorf("unknown access level %q", s)
+	}
+}
+
+// loadWhitelist reads a newline-delimited list of client identities,
+// ignoring blank lines and '#' comments.
+func loadWhitelist(path string) (map[string]struct{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading whitelist %q: %w", path, err)
+	}
+	wl := make(map[string]struct{})
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		wl[line] = struct{}{}
+	}
+	return wl, nil
+}
@@ -0,0 +1,62 @@
+package dex
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestNewDexFromConfig(t *testing.T) {
+	d, err := NewDexFromConfig("testdata/dex.yaml")
+	if err != nil {
+		t.Fatalf("NewDexFromConfig: %v", err)
+	}
+
+	var handled string
+	d.RegisterHandler("orders", func(ctx context.Context, ev Event) error { handled = "orders"; return nil })
+	d.RegisterHandler("orders-fallback", func(ctx context.Context, ev Event) error { handled = "orders-fallback"; return nil })
+	d.RegisterHandler("admin", func(ctx context.Context, ev Event) error { handled = "admin"; return nil })
+
+	if err := d.Handle(context.Background(), Event{Name: "order.created"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if handled != "orders" {
+		t.Fatalf("got %q, want the more specific route to win", handled)
+	}
+
+	if err := d.Handle(context.Background(), Event{Name: "order.shipped"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if handled != "orders-fallback" {
+		t.Fatalf("got %q, want the fallback route", handled)
+	}
+
+	if err := d.Handle(context.Background(), Event{Name: "admin.ban", Identity: "alice"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if handled != "admin" {
+		t.Fatalf("got %q, want admin route", handled)
+	}
+
+	if err := d.Handle(context.Background(), Event{Name: "admin.ban", Identity: "mallory"}); err != ErrAccessDenied {
+		t.Fatalf("got %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestNewDexFromConfigMissing(t *testing.T) {
+	if _, err := NewDexFromConfig("testdata/does-not-exist.yaml"); err == nil {
+		t.Fatal("expected error for missing config")
+	}
+}
+
+func TestNewDexFromConfigBadAccessLevel(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/dex.yaml"
+	if err := os.WriteFile(path, []byte("routes:\n  - event: \"a.*\"\n    handler: \"h\"\n    access: Nope\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := NewDexFromConfig(path); err == nil {
+		t.Fatal("expected error for unknown access level")
+	}
+}
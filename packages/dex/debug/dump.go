@@ -0,0 +1,106 @@
+package debug
+
+import (
+	"fmt"
+	"go/ast"
+	"io"
+	"reflect"
+)
+
+// dumpAST writes an indented tree dump of node to w: one line per
+// field, showing the node kind for structs/pointers and the literal
+// value for leaves, in the spirit of the syntax tree dumper in
+// go/syntax. Nodes already visited are printed as a "#N (seen)"
+// back-reference via a map[ast.Node]int seen-set instead of being
+// walked again, so shared or cyclic structure can't recurse forever.
+//
+// Interface values (every ast.Expr/ast.Stmt field) are unwrapped
+// transparently without touching the seen-set themselves; only the
+// underlying pointer, one reflect step deeper, is registered and
+// checked. Registering at both steps would mark a node "seen" the
+// moment its interface wrapper is noticed, before its pointer (and
+// therefore its fields) is ever walked.
+func dumpAST(w io.Writer, node ast.Node) {
+	seen := make(map[ast.Node]int)
+	dump(w, "", reflect.ValueOf(node), seen)
+}
+
+func dump(w io.Writer, indent string, v reflect.Value, seen map[ast.Node]int) {
+	if !v.IsValid() {
+		fmt.Fprintf(w, "%s<nil>\n", indent)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			fmt.Fprintf(w, "%s<nil>\n", indent)
+			return
+		}
+		dump(w, indent, v.Elem(), seen)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			fmt.Fprintf(w, "%s<nil>\n", indent)
+			return
+		}
+		if n, ok := nodeOf(v); ok {
+			if id, ok := seen[n]; ok {
+				fmt.Fprintf(w, "%s%s #%d (seen)\n", indent, kindOf(v), id)
+				return
+			}
+			id := len(seen)
+			seen[n] = id
+			fmt.Fprintf(w, "%s%s #%d\n", indent, kindOf(v), id)
+			dump(w, indent+"  ", v.Elem(), seen)
+			return
+		}
+		dump(w, indent, v.Elem(), seen)
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fv := v.Field(i)
+			switch fv.Kind() {
+			case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Array:
+				fmt.Fprintf(w, "%s%s:\n", indent, f.Name)
+				dump(w, indent+"  ", fv, seen)
+			default:
+				fmt.Fprintf(w, "%s%s: %v\n", indent, f.Name, fv.Interface())
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			fmt.Fprintf(w, "%s[]\n", indent)
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			fmt.Fprintf(w, "%s[%d]:\n", indent, i)
+			dump(w, indent+"  ", v.Index(i), seen)
+		}
+
+	default:
+		fmt.Fprintf(w, "%s%v\n", indent, v.Interface())
+	}
+}
+
+func nodeOf(v reflect.Value) (ast.Node, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	n, ok := v.Interface().(ast.Node)
+	return n, ok
+}
+
+func kindOf(v reflect.Value) string {
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,40 @@
+package debug
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestDumpASTSharedNode(t *testing.T) {
+	// X and Y are the very same *ast.Ident, which a real parse would
+	// never produce but which exercises the seen-set the same way a
+	// cyclic graph would: the second visit must be a back-reference,
+	// not another full walk.
+	shared := &ast.Ident{Name: "x"}
+	expr := &ast.BinaryExpr{X: shared, Y: shared, Op: token.ADD}
+
+	var buf bytes.Buffer
+	dumpAST(&buf, expr)
+	out := buf.String()
+
+	if strings.Count(out, "Name: x") != 1 {
+		t.Fatalf("expected the shared node's leaf value to be printed exactly once, got:\n%s", out)
+	}
+	if !strings.Contains(out, "(seen)") {
+		t.Fatalf("expected a (seen) back-reference on the second visit, got:\n%s", out)
+	}
+}
+
+func TestDumpASTNilPointer(t *testing.T) {
+	var expr *ast.BinaryExpr
+	var buf bytes.Buffer
+	// Passing a typed nil pointer as the ast.Node exercises the
+	// nil-pointer branch rather than the nil-interface one.
+	dumpAST(&buf, expr)
+	if got := buf.String(); !strings.Contains(got, "<nil>") {
+		t.Fatalf("got %q, want a <nil> marker", got)
+	}
+}
@@ -0,0 +1,147 @@
+// Package debug exposes a dev-mode introspection endpoint for a
+// running Dex, so operators can see what it will actually dispatch to
+// without restarting it.
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+
+	"github.com/yethikrishna/levelcode/dex"
+	"github.com/yethikrishna/levelcode/dex/gen"
+)
+
+// Handlers serves a human-readable dump of a Dex's registered routes
+// at /debug/dex/handlers.
+type Handlers struct {
+	d       *dex.Dex
+	source  *gen.Package
+	access  dex.AccessLevel
+	trusted map[string]struct{}
+}
+
+// Option configures a Handlers dumper.
+type Option func(*Handlers)
+
+// WithSource attaches the gen.Package parsed for the handlers d
+// dispatches to, so routes produced by dex/gen are dumped with their
+// parsed AST summary.
+func WithSource(pkg *gen.Package) Option {
+	return func(h *Handlers) { h.source = pkg }
+}
+
+// WithAccess overrides the access level required to view the dump.
+// Trusted by default, matching the rest of the dex access-control
+// layer.
+func WithAccess(level dex.AccessLevel) Option {
+	return func(h *Handlers) { h.access = level }
+}
+
+// WithWhitelist sets the client identities allowed to view the dump,
+// as required by the Known and Trusted access levels.
+func WithWhitelist(identities ...string) Option {
+	return func(h *Handlers) {
+		h.trusted = make(map[string]struct{}, len(identities))
+		for _, id := range identities {
+			h.trusted[id] = struct{}{}
+		}
+	}
+}
+
+// NewHandler returns an http.Handler serving a dump of d's registered
+// routes, gated by the same access-control layer as regular events
+// (Trusted only by default).
+func NewHandler(d *dex.Dex, opts ...Option) http.Handler {
+	h := &Handlers{d: d, access: dex.Trusted}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handlers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// X-Dex-Identity is trusted as-is: this handler assumes something
+	// upstream (mTLS terminating proxy, reverse proxy stripping and
+	// re-setting the header from a verified identity) has already
+	// authenticated the caller before this endpoint is reachable. This
+	// package does not itself verify the header, and it must never be
+	// exposed directly to untrusted clients.
+	identity := r.Header.Get("X-Dex-Identity")
+	if err := h.authorize(identity); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, route := range h.d.Routes() {
+		fmt.Fprintf(&buf, "pattern: %s\n", route.Pattern)
+		fmt.Fprintf(&buf, "handler: %s\n", route.Handler)
+		fmt.Fprintf(&buf, "access: %s\n", route.Access)
+
+		if fn, ok := h.d.HandlerFunc(route.Handler); ok {
+			fmt.Fprintf(&buf, "source: %s\n", sourceLocation(fn))
+		}
+
+		if method := h.findGenMethod(route.Pattern); method != nil && method.Decl != nil {
+			fmt.Fprintln(&buf, "ast:")
+			dumpAST(&buf, method.Decl)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(buf.Bytes())
+}
+
+// authorize mirrors dex's own route authorization so the debug
+// endpoint can't be used to bypass the access control it's reporting
+// on.
+func (h *Handlers) authorize(identity string) error {
+	switch h.access {
+	case dex.Public:
+		return nil
+	case dex.Identified:
+		if identity == "" {
+			return dex.ErrAccessDenied
+		}
+		return nil
+	case dex.Known, dex.Trusted:
+		if identity == "" {
+			return dex.ErrAccessDenied
+		}
+		if _, ok := h.trusted[identity]; !ok {
+			return dex.ErrAccessDenied
+		}
+		return nil
+	default:
+		return dex.ErrAccessDenied
+	}
+}
+
+func (h *Handlers) findGenMethod(pattern string) *gen.Method {
+	if h.source == nil {
+		return nil
+	}
+	for _, svc := range h.source.Services {
+		for i := range svc.Methods {
+			if svc.Methods[i].Pattern == pattern {
+				return &svc.Methods[i]
+			}
+		}
+	}
+	return nil
+}
+
+func sourceLocation(fn dex.Handler) string {
+	pc := reflect.ValueOf(fn).Pointer()
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return "unknown"
+	}
+	file, line := f.FileLine(pc)
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,98 @@
+package debug
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yethikrishna/levelcode/dex"
+	"github.com/yethikrishna/levelcode/dex/gen"
+)
+
+func newTestDex(t *testing.T) *dex.Dex {
+	t.Helper()
+	d := dex.New()
+	d.ClearMiddleware()
+	if err := d.AddRoute("user.create", "handleCreateUser", dex.Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("handleCreateUser", func(ctx context.Context, ev dex.Event) error { return nil })
+	return d
+}
+
+func TestHandlersDenyWithoutTrustedIdentity(t *testing.T) {
+	d := newTestDex(t)
+	h := NewHandler(d, WithWhitelist("alice"))
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/dex/handlers", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestHandlersAllowsTrustedIdentity(t *testing.T) {
+	d := newTestDex(t)
+	h := NewHandler(d, WithWhitelist("alice"))
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/dex/handlers", nil)
+	req.Header.Set("X-Dex-Identity", "alice")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "pattern: user.create") {
+		t.Fatalf("got body %q, want it to mention the configured route", body)
+	}
+	if !strings.Contains(body, "handler: handleCreateUser") {
+		t.Fatalf("got body %q, want it to mention the handler name", body)
+	}
+}
+
+func TestHandlersWithPublicAccess(t *testing.T) {
+	d := newTestDex(t)
+	h := NewHandler(d, WithAccess(dex.Public))
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/dex/handlers", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestHandlersDumpsGenAST(t *testing.T) {
+	pkg, err := gen.ParseDir("../gen/testdata/sample")
+	if err != nil {
+		t.Fatalf("ParseDir: %v", err)
+	}
+
+	d := dex.New()
+	d.ClearMiddleware()
+	if err := d.AddRoute("user.create", "handleCreateUser", dex.Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("handleCreateUser", func(ctx context.Context, ev dex.Event) error { return nil })
+
+	h := NewHandler(d, WithAccess(dex.Public), WithSource(pkg))
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/dex/handlers", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "ast:") {
+		t.Fatalf("got body %q, want an ast: section for the gen-produced route", body)
+	}
+	if !strings.Contains(body, "FuncDecl") {
+		t.Fatalf("got body %q, want the dumped node kind to appear", body)
+	}
+}
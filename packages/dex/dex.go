@@ -0,0 +1,213 @@
+// Package dex implements a configurable event dispatcher that routes
+// named events to registered handlers under per-route access control.
+package dex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gobwas/glob"
+)
+
+// AccessLevel describes how strongly a client's identity must be
+// established before a route may be invoked.
+type AccessLevel int
+
+const (
+	// Public routes accept any event, identified or not.
+	Public AccessLevel = iota
+	// Identified routes require a non-empty Identity.
+	Identified
+	// Known routes require the Identity to appear on the route's whitelist.
+	Known
+	// Trusted routes require the Identity to appear on the route's
+	// whitelist and are reserved for sensitive operations.
+	Trusted
+)
+
+// String implements fmt.Stringer.
+func (l AccessLevel) String() string {
+	switch l {
+	case Public:
+		return "Public"
+	case Identified:
+		return "Identified"
+	case Known:
+		return "Known"
+	case Trusted:
+		return "Trusted"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a single occurrence dispatched into a Dex.
+type Event struct {
+	Name     string
+	Payload  []byte
+	Identity string
+	Headers  map[string]string
+}
+
+// Handler processes a single Event.
+type Handler func(context.Context, Event) error
+
+// Middleware wraps a Handler to add cross-cutting behavior such as
+// logging, recovery, or rate limiting.
+type Middleware func(Handler) Handler
+
+// Dex dispatches named events to registered handlers according to a set
+// of configured routes.
+type Dex struct {
+	routes   []route
+	handlers map[string]Handler
+	mw       []Middleware
+}
+
+// route is a single configured entry: events matching pattern are
+// dispatched to handler, subject to access and, for Known/Trusted
+// routes, whitelist.
+type route struct {
+	pattern   glob.Glob
+	raw       string
+	handler   string
+	access    AccessLevel
+	whitelist map[string]struct{}
+}
+
+// New returns an empty Dex with no routes or handlers registered and
+// the default logging middleware installed. Use NewDexFromConfig to
+// load routes from a YAML file instead, or ClearMiddleware to opt out
+// of the default logging.
+func New() *Dex {
+	d := &Dex{handlers: make(map[string]Handler)}
+	d.Use(LoggingMiddleware(nil))
+	return d
+}
+
+// AddRoute registers a route programmatically, equivalent to a single
+// entry in a YAML config loaded by NewDexFromConfig. It is mainly
+// useful for embedding a Dex in a larger program without a config file.
+// Routes added this way have no whitelist, so a Known or Trusted route
+// added through AddRoute rejects every identity.
+func (d *Dex) AddRoute(eventGlob, handler string, access AccessLevel) error {
+	g, err := glob.Compile(eventGlob)
+	if err != nil {
+		return fmt.Errorf("dex: route %q: invalid event glob: %w", eventGlob, err)
+	}
+	d.routes = append(d.routes, route{pattern: g, raw: eventGlob, handler: handler, access: access})
+	return nil
+}
+
+// RegisterHandler associates name with fn so that routes referencing name
+// in their config can dispatch to it. Registering the same name twice
+// replaces the previous handler.
+func (d *Dex) RegisterHandler(name string, fn Handler) {
+	if d.handlers == nil {
+		d.handlers = make(map[string]Handler)
+	}
+	d.handlers[name] = fn
+}
+
+// Use appends mw to the Dex's middleware chain. Middlewares registered
+// earlier wrap those registered later, so the first Middleware passed
+// to Use runs first and decides whether/how the rest of the chain, down
+// to the resolved handler, runs (LIFO wrapping around the innermost
+// handler).
+func (d *Dex) Use(mw ...Middleware) {
+	d.mw = append(d.mw, mw...)
+}
+
+// ClearMiddleware removes every middleware registered so far, including
+// the default logging middleware installed by New. Call it before Use
+// to opt out of the default behavior entirely.
+func (d *Dex) ClearMiddleware() {
+	d.mw = nil
+}
+
+// Handle resolves ev against the configured routes, enforces the route's
+// access level against ev.Identity, and runs the matching handler
+// through the registered middleware chain.
+func (d *Dex) Handle(ctx context.Context, ev Event) error {
+	r, err := d.resolve(ev.Name)
+	if err != nil {
+		return err
+	}
+	if err := r.authorize(ev.Identity); err != nil {
+		return err
+	}
+	fn, ok := d.handlers[r.handler]
+	if !ok {
+		return fmt.Errorf("dex: handler %q not registered", r.handler)
+	}
+	return d.chain(fn)(ctx, ev)
+}
+
+// chain builds the Handler that Handle ultimately invokes: each
+// registered middleware wraps the next, with core (the resolved
+// handler) innermost.
+func (d *Dex) chain(core Handler) Handler {
+	h := core
+	for i := len(d.mw) - 1; i >= 0; i-- {
+		h = d.mw[i](h)
+	}
+	return h
+}
+
+// RouteInfo is a read-only view of a configured route, for admin and
+// debugging tools.
+type RouteInfo struct {
+	Pattern string
+	Handler string
+	Access  AccessLevel
+}
+
+// Routes returns the currently configured routes in declaration order.
+func (d *Dex) Routes() []RouteInfo {
+	infos := make([]RouteInfo, len(d.routes))
+	for i, r := range d.routes {
+		infos[i] = RouteInfo{Pattern: r.raw, Handler: r.handler, Access: r.access}
+	}
+	return infos
+}
+
+// HandlerFunc returns the Handler registered under name, if any.
+func (d *Dex) HandlerFunc(name string) (Handler, bool) {
+	fn, ok := d.handlers[name]
+	return fn, ok
+}
+
+// resolve returns the first configured route whose pattern matches name.
+// Routes are matched in declaration order, so more specific globs should
+// be declared before broader ones.
+func (d *Dex) resolve(name string) (route, error) {
+	for _, r := range d.routes {
+		if r.pattern.Match(name) {
+			return r, nil
+		}
+	}
+	return route{}, fmt.Errorf("dex: no route matches event %q", name)
+}
+
+// authorize checks identity against the route's access level.
+func (r route) authorize(identity string) error {
+	switch r.access {
+	case Public:
+		return nil
+	case Identified:
+		if identity == "" {
+			return ErrAccessDenied
+		}
+		return nil
+	case Known, Trusted:
+		if identity == "" {
+			return ErrAccessDenied
+		}
+		if _, ok := r.whitelist[identity]; !ok {
+			return ErrAccessDenied
+		}
+		return nil
+	default:
+		return ErrAccessDenied
+	}
+}
@@ -0,0 +1,115 @@
+package dex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gobwas/glob"
+)
+
+func mustGlob(t *testing.T, pattern string) glob.Glob {
+	t.Helper()
+	g, err := glob.Compile(pattern)
+	if err != nil {
+		t.Fatalf("glob.Compile(%q): %v", pattern, err)
+	}
+	return g
+}
+
+func TestHandleGlobPrecedence(t *testing.T) {
+	d := New()
+	d.routes = []route{
+		{pattern: mustGlob(t, "user.created"), raw: "user.created", handler: "specific", access: Public},
+		{pattern: mustGlob(t, "user.*"), raw: "user.*", handler: "wildcard", access: Public},
+	}
+
+	var got string
+	d.RegisterHandler("specific", func(ctx context.Context, ev Event) error { got = "specific"; return nil })
+	d.RegisterHandler("wildcard", func(ctx context.Context, ev Event) error { got = "wildcard"; return nil })
+
+	if err := d.Handle(context.Background(), Event{Name: "user.created"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if got != "specific" {
+		t.Fatalf("got %q, want the first declared matching route to win", got)
+	}
+}
+
+func TestHandleAccessLevels(t *testing.T) {
+	d := New()
+	d.routes = []route{
+		{pattern: mustGlob(t, "public.*"), raw: "public.*", handler: "h", access: Public},
+		{pattern: mustGlob(t, "ident.*"), raw: "ident.*", handler: "h", access: Identified},
+		{
+			pattern:   mustGlob(t, "admin.*"),
+			raw:       "admin.*",
+			handler:   "h",
+			access:    Known,
+			whitelist: map[string]struct{}{"alice": {}},
+		},
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error { return nil })
+
+	tests := []struct {
+		name     string
+		event    string
+		identity string
+		wantErr  error
+	}{
+		{"public anonymous", "public.ping", "", nil},
+		{"identified with identity", "ident.ping", "alice", nil},
+		{"identified without identity", "ident.ping", "", ErrAccessDenied},
+		{"known whitelisted", "admin.delete", "alice", nil},
+		{"known not whitelisted", "admin.delete", "mallory", ErrAccessDenied},
+		{"known anonymous", "admin.delete", "", ErrAccessDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := d.Handle(context.Background(), Event{Name: tt.event, Identity: tt.identity})
+			if err != tt.wantErr {
+				t.Fatalf("got err %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAddRoute(t *testing.T) {
+	d := New()
+	if err := d.AddRoute("greet.*", "greeter", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+
+	var got string
+	d.RegisterHandler("greeter", func(ctx context.Context, ev Event) error { got = ev.Name; return nil })
+
+	if err := d.Handle(context.Background(), Event{Name: "greet.hello"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if got != "greet.hello" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestAddRouteBadGlob(t *testing.T) {
+	d := New()
+	if err := d.AddRoute("[", "h", Public); err == nil {
+		t.Fatal("expected error for invalid glob")
+	}
+}
+
+func TestHandleNoRoute(t *testing.T) {
+	d := New()
+	if err := d.Handle(context.Background(), Event{Name: "unknown.event"}); err == nil {
+		t.Fatal("expected error for event with no matching route")
+	}
+}
+
+func TestHandleUnregisteredHandler(t *testing.T) {
+	d := New()
+	d.routes = []route{{pattern: mustGlob(t, "any.*"), raw: "any.*", handler: "missing", access: Public}}
+
+	if err := d.Handle(context.Background(), Event{Name: "any.thing"}); err == nil {
+		t.Fatal("expected error for unregistered handler")
+	}
+}
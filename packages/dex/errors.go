@@ -0,0 +1,11 @@
+package dex
+
+import "errors"
+
+// ErrAccessDenied is returned by Handle when an event's Identity does not
+// satisfy the access level required by the route it resolves to.
+var ErrAccessDenied = errors.New("dex: access denied")
+
+// ErrRateLimited is returned by RateLimitMiddleware when an event's
+// route has exceeded its configured rate limit.
+var ErrRateLimited = errors.New("dex: rate limited")
@@ -0,0 +1,172 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// defDoc is the JSON-serializable form of a Package, written to
+// def.json for consumption by other tools.
+type defDoc struct {
+	Services []serviceDoc `json:"services"`
+}
+
+type serviceDoc struct {
+	Name    string      `json:"name"`
+	Methods []methodDoc `json:"methods"`
+}
+
+type methodDoc struct {
+	Name    string     `json:"name"`
+	Comment string     `json:"comment"`
+	Pattern string     `json:"pattern"`
+	Params  []fieldDoc `json:"params"`
+	Results []fieldDoc `json:"results"`
+}
+
+type fieldDoc struct {
+	Name       string `json:"name"`
+	Comment    string `json:"comment"`
+	Type       string `json:"type"`
+	IsMultiple bool   `json:"isMultiple"`
+}
+
+// Generate writes dex_handlers.go and def.json for pkg into outDir.
+func Generate(pkg *Package, outDir string) error {
+	handlersSrc, err := renderHandlers(pkg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outDir, "dex_handlers.go"), handlersSrc, 0o644); err != nil {
+		return fmt.Errorf("gen: writing dex_handlers.go: %w", err)
+	}
+
+	defJSON, err := json.MarshalIndent(toDefDoc(pkg), "", "  ")
+	if err != nil {
+		return fmt.Errorf("gen: marshaling def.json: %w", err)
+	}
+	defJSON = append(defJSON, '\n')
+	if err := os.WriteFile(filepath.Join(outDir, "def.json"), defJSON, 0o644); err != nil {
+		return fmt.Errorf("gen: writing def.json: %w", err)
+	}
+
+	return nil
+}
+
+func toDefDoc(pkg *Package) defDoc {
+	var doc defDoc
+	for _, svc := range pkg.Services {
+		var methods []methodDoc
+		for _, m := range svc.Methods {
+			methods = append(methods, methodDoc{
+				Name:    m.Name,
+				Comment: m.Comment,
+				Pattern: m.Pattern,
+				Params:  toFieldDocs(m.Params),
+				Results: toFieldDocs(m.Results),
+			})
+		}
+		doc.Services = append(doc.Services, serviceDoc{Name: svc.Name, Methods: methods})
+	}
+	return doc
+}
+
+func toFieldDocs(fields []Field) []fieldDoc {
+	var docs []fieldDoc
+	for _, f := range fields {
+		docs = append(docs, fieldDoc{Name: f.Name, Comment: f.Comment, Type: f.Type, IsMultiple: f.IsMultiple})
+	}
+	return docs
+}
+
+var handlersTemplate = template.Must(template.New("dex_handlers.go").Parse(`// Code generated by dex/gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/yethikrishna/levelcode/dex"
+)
+{{range .Handlers}}
+func {{.AdapterName}}(ctx context.Context, ev dex.Event) error {
+	var arg {{.ArgType}}
+	if err := json.Unmarshal(ev.Payload, &arg); err != nil {
+		return err
+	}
+	return {{.Call}}
+}
+{{end}}
+// RegisterGenerated registers every handler discovered by dex/gen with d.
+func RegisterGenerated(d *dex.Dex) {
+{{- range .Handlers}}
+	d.RegisterHandler({{printf "%q" .Pattern}}, {{.AdapterName}})
+{{- end}}
+}
+`))
+
+type handlerView struct {
+	AdapterName string
+	ArgType     string
+	Call        string
+	Pattern     string
+}
+
+type handlersView struct {
+	Package  string
+	Handlers []handlerView
+}
+
+func renderHandlers(pkg *Package) ([]byte, error) {
+	view := handlersView{Package: pkg.Name}
+	for _, svc := range pkg.Services {
+		for _, m := range svc.Methods {
+			if err := validateHandlerSignature(m); err != nil {
+				return nil, err
+			}
+
+			call := fmt.Sprintf("%s(arg)", m.Name)
+			if svc.Name != "" {
+				call = fmt.Sprintf("(&%s{}).%s(arg)", svc.Name, m.Name)
+			}
+
+			view.Handlers = append(view.Handlers, handlerView{
+				AdapterName: "handle" + svc.Name + m.Name,
+				ArgType:     m.Params[0].Type,
+				Call:        call,
+				Pattern:     m.Pattern,
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := handlersTemplate.Execute(&buf, view); err != nil {
+		return nil, fmt.Errorf("gen: rendering dex_handlers.go: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("gen: formatting dex_handlers.go: %w", err)
+	}
+	return formatted, nil
+}
+
+// validateHandlerSignature rejects annotated functions the generated
+// adapter can't wrap: it always unmarshals a single JSON payload into
+// exactly one argument and returns whatever error that call produces,
+// so m must take exactly one parameter and return exactly one error.
+func validateHandlerSignature(m Method) error {
+	if len(m.Params) != 1 {
+		return fmt.Errorf("gen: %s: dex:event handlers must take exactly one parameter, got %d", m.Name, len(m.Params))
+	}
+	if len(m.Results) != 1 || m.Results[0].Type != "error" {
+		return fmt.Errorf("gen: %s: dex:event handlers must return exactly one error", m.Name)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package gen
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateDefJSON(t *testing.T) {
+	pkg, err := ParseDir("testdata/sample")
+	if err != nil {
+		t.Fatalf("ParseDir: %v", err)
+	}
+
+	got, err := json.MarshalIndent(toDefDoc(pkg), "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent: %v", err)
+	}
+	got = append(got, '\n')
+
+	want, err := os.ReadFile("testdata/sample/golden/def.json")
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	if string(got) != string(want) {
+		t.Fatalf("def.json mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateHandlersCompiles(t *testing.T) {
+	pkg, err := ParseDir("testdata/sample")
+	if err != nil {
+		t.Fatalf("ParseDir: %v", err)
+	}
+
+	// The generated dex_handlers.go references types declared alongside
+	// it (CreateUserRequest, Billing, ...), so it must be written next
+	// to a copy of the fixture source and actually built, not just
+	// checked for existence, or a template bug that still produces
+	// well-formed-but-uncompilable Go goes unnoticed.
+	dir, err := os.MkdirTemp("testdata", "generated-")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	src, err := os.ReadFile("testdata/sample/handlers.go")
+	if err != nil {
+		t.Fatalf("reading fixture source: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "handlers.go"), src, 0o644); err != nil {
+		t.Fatalf("copying fixture source: %v", err)
+	}
+
+	if err := Generate(pkg, dir); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, name := range []string{"dex_handlers.go", "def.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Fatalf("expected %s to be written: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command("go", "build", "./"+dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated package does not build: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateRejectsBadHandlerSignature(t *testing.T) {
+	pkg, err := ParseDir("testdata/invalid")
+	if err != nil {
+		t.Fatalf("ParseDir: %v", err)
+	}
+
+	err = Generate(pkg, t.TempDir())
+	if err == nil {
+		t.Fatal("expected Generate to reject a zero-arg dex:event handler")
+	}
+	if !strings.Contains(err.Error(), "Ping") {
+		t.Fatalf("got error %q, want it to name the offending handler", err)
+	}
+}
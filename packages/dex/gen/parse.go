@@ -0,0 +1,144 @@
+// Package gen parses an annotated Go package and derives the handler
+// metadata needed to wire its functions into a dex.Dex dispatcher.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// directivePattern matches a "dex:event "<glob>"" directive anywhere in
+// a function's doc comment.
+var directivePattern = regexp.MustCompile(`dex:event\s+"([^"]*)"`)
+
+// Package describes the handlers discovered in a single Go package
+// directory.
+type Package struct {
+	Name     string
+	Services []Service
+}
+
+// Service groups the handlers declared on a single receiver type.
+// Package-level functions are grouped under a Service whose Name is
+// empty.
+type Service struct {
+	Name    string
+	Methods []Method
+}
+
+// Method is a single exported function or method annotated with a
+// dex:event directive.
+type Method struct {
+	Name    string
+	Comment string
+	Pattern string
+	Params  []Field
+	Results []Field
+	// Decl is the parsed declaration backing this Method, kept around so
+	// tools such as dex/debug can render its AST.
+	Decl *ast.FuncDecl
+}
+
+// Field is a single parameter or result of a Method.
+type Field struct {
+	Name       string
+	Comment    string
+	Type       string
+	IsMultiple bool
+}
+
+// ParseDir walks the Go package rooted at dir and returns every
+// exported function or method whose doc comment carries a
+// `dex:event "<name-glob>"` directive.
+func ParseDir(dir string) (*Package, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("gen: parsing %s: %w", dir, err)
+	}
+
+	for name, astPkg := range pkgs {
+		if strings.HasSuffix(name, "_test") {
+			continue
+		}
+
+		// doc.PreserveAST keeps function bodies intact; without it go/doc
+		// strips them to save memory, leaving Method.Decl.Body nil for
+		// every handler and nothing for dex/debug to render.
+		docPkg := doc.New(astPkg, dir, doc.AllDecls|doc.PreserveAST)
+		pkg := &Package{Name: docPkg.Name}
+
+		if funcs := collectMethods(fset, docPkg.Funcs); len(funcs) > 0 {
+			pkg.Services = append(pkg.Services, Service{Methods: funcs})
+		}
+
+		for _, t := range docPkg.Types {
+			if methods := collectMethods(fset, t.Methods); len(methods) > 0 {
+				pkg.Services = append(pkg.Services, Service{Name: t.Name, Methods: methods})
+			}
+		}
+
+		return pkg, nil
+	}
+
+	return nil, fmt.Errorf("gen: no Go package found in %s", dir)
+}
+
+func collectMethods(fset *token.FileSet, funcs []*doc.Func) []Method {
+	var methods []Method
+	for _, f := range funcs {
+		if !token.IsExported(f.Name) {
+			continue
+		}
+		pattern := directivePattern.FindStringSubmatch(f.Doc)
+		if pattern == nil {
+			continue
+		}
+		methods = append(methods, Method{
+			Name:    f.Name,
+			Comment: strings.TrimSpace(f.Doc),
+			Pattern: pattern[1],
+			Params:  fieldsOf(fset, f.Decl.Type.Params),
+			Results: fieldsOf(fset, f.Decl.Type.Results),
+			Decl:    f.Decl,
+		})
+	}
+	return methods
+}
+
+func fieldsOf(fset *token.FileSet, list *ast.FieldList) []Field {
+	if list == nil {
+		return nil
+	}
+
+	var fields []Field
+	for _, f := range list.List {
+		typ, isMultiple := typeString(fset, f.Type)
+		comment := strings.TrimSpace(f.Comment.Text())
+
+		if len(f.Names) == 0 {
+			fields = append(fields, Field{Type: typ, IsMultiple: isMultiple, Comment: comment})
+			continue
+		}
+		for _, n := range f.Names {
+			fields = append(fields, Field{Name: n.Name, Type: typ, IsMultiple: isMultiple, Comment: comment})
+		}
+	}
+	return fields
+}
+
+func typeString(fset *token.FileSet, expr ast.Expr) (string, bool) {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return "", false
+	}
+	_, isMultiple := expr.(*ast.ArrayType)
+	return buf.String(), isMultiple
+}
@@ -0,0 +1,67 @@
+package gen
+
+import "testing"
+
+func TestParseDir(t *testing.T) {
+	pkg, err := ParseDir("testdata/sample")
+	if err != nil {
+		t.Fatalf("ParseDir: %v", err)
+	}
+
+	if pkg.Name != "sample" {
+		t.Fatalf("got package name %q, want %q", pkg.Name, "sample")
+	}
+	if len(pkg.Services) != 2 {
+		t.Fatalf("got %d services, want 2 (package funcs + Billing)", len(pkg.Services))
+	}
+
+	funcs := findService(t, pkg, "")
+	if len(funcs.Methods) != 2 {
+		t.Fatalf("got %d package-level handlers, want 2 (Ping must be excluded)", len(funcs.Methods))
+	}
+	createUser := findMethod(t, funcs, "CreateUser")
+	if createUser.Pattern != "user.create" {
+		t.Fatalf("got pattern %q, want user.create", createUser.Pattern)
+	}
+	if len(createUser.Params) != 1 || createUser.Params[0].Type != "CreateUserRequest" {
+		t.Fatalf("got params %+v, want a single CreateUserRequest", createUser.Params)
+	}
+	if createUser.Params[0].IsMultiple {
+		t.Fatalf("CreateUserRequest param is not a slice, got IsMultiple=true")
+	}
+
+	notifyAll := findMethod(t, funcs, "NotifyAll")
+	if len(notifyAll.Params) != 1 || !notifyAll.Params[0].IsMultiple {
+		t.Fatalf("got params %+v, want a single []string param with IsMultiple=true", notifyAll.Params)
+	}
+
+	billing := findService(t, pkg, "Billing")
+	if len(billing.Methods) != 1 || billing.Methods[0].Name != "Charge" {
+		t.Fatalf("got billing methods %+v, want [Charge]", billing.Methods)
+	}
+	if billing.Methods[0].Pattern != "billing.charge.*" {
+		t.Fatalf("got pattern %q, want billing.charge.*", billing.Methods[0].Pattern)
+	}
+}
+
+func findService(t *testing.T, pkg *Package, name string) Service {
+	t.Helper()
+	for _, s := range pkg.Services {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("no service named %q in %+v", name, pkg.Services)
+	return Service{}
+}
+
+func findMethod(t *testing.T, svc Service, name string) Method {
+	t.Helper()
+	for _, m := range svc.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	t.Fatalf("no method named %q in %+v", name, svc.Methods)
+	return Method{}
+}
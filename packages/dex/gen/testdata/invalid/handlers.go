@@ -0,0 +1,11 @@
+// Package invalid is fixture input for gen's handler-signature
+// validation test: Ping is annotated but takes no parameters, which
+// the generated adapter can't wrap.
+package invalid
+
+// Ping responds to health checks.
+//
+// dex:event "ping"
+func Ping() error {
+	return nil
+}
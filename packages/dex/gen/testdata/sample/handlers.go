@@ -0,0 +1,40 @@
+// Package sample is fixture input for gen's golden-file tests.
+package sample
+
+// CreateUserRequest is the payload accepted by CreateUser.
+type CreateUserRequest struct {
+	// Name is the new user's display name.
+	Name string
+	// Tags are labels applied to the new user.
+	Tags []string
+}
+
+// CreateUser provisions a new user account.
+//
+// dex:event "user.create"
+func CreateUser(req CreateUserRequest) error {
+	return nil
+}
+
+// Ping responds to health checks. It has no dex:event directive and
+// must not be picked up by ParseDir.
+func Ping() error {
+	return nil
+}
+
+// NotifyAll sends a notification to a batch of users.
+//
+// dex:event "notify.batch"
+func NotifyAll(names []string) error {
+	return nil
+}
+
+// Billing groups the handlers that touch invoicing.
+type Billing struct{}
+
+// Charge bills a customer for an order.
+//
+// dex:event "billing.charge.*"
+func (b *Billing) Charge(req CreateUserRequest) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package dex
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LoggingMiddleware logs each dispatched event with request-scoped
+// fields (event name, identity, duration, and any resulting error)
+// through logger. A nil logger uses slog.Default(). New installs this
+// middleware by default, replacing the package's former
+// fmt.Println("event:", event) behavior; call ClearMiddleware to opt
+// out.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) error {
+			start := time.Now()
+			err := next(ctx, ev)
+
+			l := logger.With(
+				slog.String("event", ev.Name),
+				slog.String("identity", ev.Identity),
+				slog.Duration("duration", time.Since(start)),
+			)
+			if err != nil {
+				l.Error("dex: event handling failed", slog.Any("error", err))
+			} else {
+				l.Info("dex: event handled")
+			}
+			return err
+		}
+	}
+}
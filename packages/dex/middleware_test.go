@@ -0,0 +1,129 @@
+package dex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrdering(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	var order []string
+	track := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, ev Event) error {
+				order = append(order, name)
+				return next(ctx, ev)
+			}
+		}
+	}
+	d.Use(track("first"), track("second"))
+
+	if err := d.AddRoute("order.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error {
+		order = append(order, "handler")
+		return nil
+	})
+
+	if err := d.Handle(context.Background(), Event{Name: "order.created"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	want := []string{"first", "second", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("got order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+	d.Use(RecoverMiddleware())
+
+	if err := d.AddRoute("panic.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error {
+		panic("boom")
+	})
+
+	err := d.Handle(context.Background(), Event{Name: "panic.now"})
+	var panicErr *ErrHandlerPanic
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("got err %v, want *ErrHandlerPanic", err)
+	}
+	if panicErr.Value != "boom" {
+		t.Fatalf("got panic value %v, want boom", panicErr.Value)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Fatal("expected a captured stack trace")
+	}
+}
+
+func TestContextCancellationShortCircuits(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	var called bool
+	cancelEarly := func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) error {
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			return next(ctx, ev)
+		}
+	}
+	stopIfCanceled := func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return next(ctx, ev)
+		}
+	}
+	d.Use(cancelEarly, stopIfCanceled)
+
+	if err := d.AddRoute("cancel.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error {
+		called = true
+		return nil
+	})
+
+	err := d.Handle(context.Background(), Event{Name: "cancel.me"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got err %v, want context.Canceled", err)
+	}
+	if called {
+		t.Fatal("handler must not run once ctx is canceled upstream")
+	}
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+	d.Use(TimeoutMiddleware(10 * time.Millisecond))
+
+	if err := d.AddRoute("slow.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := d.Handle(context.Background(), Event{Name: "slow.task"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got err %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,36 @@
+package dex
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelMiddleware starts a span named after the event for each dispatch,
+// extracting any trace context carried in ev.Headers via the globally
+// configured otel.GetTextMapPropagator(). A nil tracer uses
+// otel.Tracer("dex").
+func OTelMiddleware(tracer trace.Tracer) Middleware {
+	if tracer == nil {
+		tracer = otel.Tracer("dex")
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) error {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(ev.Headers))
+
+			ctx, span := tracer.Start(ctx, ev.Name, trace.WithAttributes(
+				attribute.String("dex.identity", ev.Identity),
+			))
+			defer span.End()
+
+			err := next(ctx, ev)
+			if err != nil {
+				span.RecordError(err)
+			}
+			return err
+		}
+	}
+}
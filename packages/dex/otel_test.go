@@ -0,0 +1,85 @@
+package dex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOTelMiddlewareRecordsSpan(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	d.Use(OTelMiddleware(tp.Tracer("test")))
+
+	if err := d.AddRoute("order.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error { return nil })
+
+	if err := d.Handle(context.Background(), Event{Name: "order.created", Identity: "alice"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if got := spans[0].Name; got != "order.created" {
+		t.Fatalf("got span name %q, want %q", got, "order.created")
+	}
+}
+
+func TestOTelMiddlewareRecordsHandlerError(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := trace.NewTracerProvider(trace.WithSyncer(exporter))
+	d.Use(OTelMiddleware(tp.Tracer("test")))
+
+	wantErr := errors.New("boom")
+	if err := d.AddRoute("order.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error { return wantErr })
+
+	if err := d.Handle(context.Background(), Event{Name: "order.created"}); !errors.Is(err, wantErr) {
+		t.Fatalf("got err %v, want %v", err, wantErr)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if events := spans[0].Events; len(events) == 0 || events[0].Name != "exception" {
+		t.Fatalf("got span events %v, want a recorded exception", events)
+	}
+}
+
+func TestOTelMiddlewareDefaultTracer(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+	d.Use(OTelMiddleware(nil))
+
+	if err := d.AddRoute("order.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	var called bool
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error {
+		called = true
+		return nil
+	})
+
+	if err := d.Handle(context.Background(), Event{Name: "order.created"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if !called {
+		t.Fatal("expected handler to run with the default otel.Tracer(\"dex\")")
+	}
+}
@@ -0,0 +1,53 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gobwas/glob"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitMiddleware throttles events per route. limits maps an event
+// glob, matched the same way route event globs are, to the
+// rate.Limiter that should guard it; events that don't match any key
+// are not rate limited. Patterns are tried in the lexical order of
+// their source glob string, and the first one to match an event wins,
+// so a map with multiple overlapping patterns has well-defined,
+// reproducible behavior despite Go's randomized map iteration order.
+//
+// It returns an error if any glob in limits fails to compile, the same
+// way AddRoute rejects an invalid event glob.
+func RateLimitMiddleware(limits map[string]*rate.Limiter) (Middleware, error) {
+	type compiledLimit struct {
+		raw     string
+		pattern glob.Glob
+		limiter *rate.Limiter
+	}
+
+	var compiled []compiledLimit
+	for pattern, limiter := range limits {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("dex: rate limit %q: invalid event glob: %w", pattern, err)
+		}
+		compiled = append(compiled, compiledLimit{raw: pattern, pattern: g, limiter: limiter})
+	}
+	sort.Slice(compiled, func(i, j int) bool { return compiled[i].raw < compiled[j].raw })
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) error {
+			for _, c := range compiled {
+				if !c.pattern.Match(ev.Name) {
+					continue
+				}
+				if !c.limiter.Allow() {
+					return ErrRateLimited
+				}
+				break
+			}
+			return next(ctx, ev)
+		}
+	}, nil
+}
@@ -0,0 +1,97 @@
+package dex
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimitMiddlewareBlocksMatchingRoute(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	mw, err := RateLimitMiddleware(map[string]*rate.Limiter{
+		"limited.*": rate.NewLimiter(0, 0), // never allows
+	})
+	if err != nil {
+		t.Fatalf("RateLimitMiddleware: %v", err)
+	}
+	d.Use(mw)
+
+	if err := d.AddRoute("limited.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error { return nil })
+
+	err = d.Handle(context.Background(), Event{Name: "limited.now"})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got err %v, want ErrRateLimited", err)
+	}
+}
+
+func TestRateLimitMiddlewareDefaultsToUnlimited(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	mw, err := RateLimitMiddleware(map[string]*rate.Limiter{
+		"limited.*": rate.NewLimiter(0, 0), // never allows
+	})
+	if err != nil {
+		t.Fatalf("RateLimitMiddleware: %v", err)
+	}
+	d.Use(mw)
+
+	if err := d.AddRoute("other.*", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	var called bool
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error {
+		called = true
+		return nil
+	})
+
+	if err := d.Handle(context.Background(), Event{Name: "other.now"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if !called {
+		t.Fatal("expected handler to run for an event matching no rate limit")
+	}
+}
+
+func TestRateLimitMiddlewareFirstMatchWins(t *testing.T) {
+	d := New()
+	d.ClearMiddleware()
+
+	// Both patterns match "order.created", but patterns are tried in
+	// lexical order ("order.*" < "order.created"), so the blocking
+	// limiter on "order.*" must be the one consulted.
+	mw, err := RateLimitMiddleware(map[string]*rate.Limiter{
+		"order.*":       rate.NewLimiter(0, 0), // never allows
+		"order.created": rate.NewLimiter(rate.Inf, 1),
+	})
+	if err != nil {
+		t.Fatalf("RateLimitMiddleware: %v", err)
+	}
+	d.Use(mw)
+
+	if err := d.AddRoute("order.created", "h", Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	d.RegisterHandler("h", func(ctx context.Context, ev Event) error { return nil })
+
+	err = d.Handle(context.Background(), Event{Name: "order.created"})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got err %v, want ErrRateLimited from the matching limiter", err)
+	}
+}
+
+func TestRateLimitMiddlewareInvalidGlob(t *testing.T) {
+	_, err := RateLimitMiddleware(map[string]*rate.Limiter{
+		"[invalid": rate.NewLimiter(1, 1),
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid event glob")
+	}
+}
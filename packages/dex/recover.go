@@ -0,0 +1,35 @@
+package dex
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrHandlerPanic is returned by RecoverMiddleware when a handler
+// panics. It captures the recovered value and the stack trace at the
+// point of the panic so it can be logged or reported upstream.
+type ErrHandlerPanic struct {
+	Value interface{}
+	Stack []byte
+}
+
+// Error implements error.
+func (e *ErrHandlerPanic) Error() string {
+	return fmt.Sprintf("dex: handler panicked: %v", e.Value)
+}
+
+// RecoverMiddleware converts a panic in the wrapped Handler into an
+// *ErrHandlerPanic instead of crashing the dispatcher.
+func RecoverMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = &ErrHandlerPanic{Value: r, Stack: debug.Stack()}
+				}
+			}()
+			return next(ctx, ev)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+
+	"github.com/yethikrishna/levelcode/dex"
+	dexv1 "github.com/yethikrishna/levelcode/dex/auto/rpc/dex/v1"
+)
+
+// Client dispatches events to a remote DexService and streams events
+// back from it.
+type Client interface {
+	// Handle sends ev to the remote Dex and returns the error it
+	// reported, if any.
+	Handle(ctx context.Context, ev dex.Event) error
+
+	// Subscribe streams events matching nameGlob from the remote Dex.
+	// An empty nameGlob subscribes to every event.
+	Subscribe(ctx context.Context, nameGlob string) (dexv1.DexService_SubscribeClient, error)
+}
+
+type client struct {
+	c dexv1.DexServiceClient
+}
+
+// NewClient returns a Client that issues requests over cc.
+func NewClient(cc *grpc.ClientConn) Client {
+	return &client{c: dexv1.NewDexServiceClient(cc)}
+}
+
+func (c *client) Handle(ctx context.Context, ev dex.Event) error {
+	headers := map[string]string{}
+	for k, v := range ev.Headers {
+		headers[k] = v
+	}
+	if ev.Identity != "" {
+		headers["identity"] = ev.Identity
+	}
+
+	resp, err := c.c.Handle(ctx, &dexv1.EventRequest{
+		Event: &dexv1.Event{
+			Name:    ev.Name,
+			Payload: ev.Payload,
+			Headers: headers,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if resp.GetError() != "" {
+		return errors.New(resp.GetError())
+	}
+	return nil
+}
+
+func (c *client) Subscribe(ctx context.Context, nameGlob string) (dexv1.DexService_SubscribeClient, error) {
+	return c.c.Subscribe(ctx, &dexv1.SubscribeRequest{NameGlob: nameGlob})
+}
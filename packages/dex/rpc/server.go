@@ -0,0 +1,119 @@
+// Package rpc exposes a Dex's dispatcher over gRPC.
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"google.golang.org/grpc"
+
+	"github.com/yethikrishna/levelcode/dex"
+	dexv1 "github.com/yethikrishna/levelcode/dex/auto/rpc/dex/v1"
+)
+
+// dexServer adapts a *dex.Dex to the DexService gRPC contract, fanning
+// out every handled event to any active Subscribe streams whose glob
+// matches it.
+type dexServer struct {
+	dexv1.UnimplementedDexServiceServer
+
+	d *dex.Dex
+
+	mu          sync.Mutex
+	subscribers map[int]subscriber
+	nextID      int
+}
+
+type subscriber struct {
+	pattern glob.Glob
+	ch      chan *dexv1.Event
+}
+
+// NewServer returns a *grpc.Server with d's dispatcher registered as
+// the DexService implementation.
+func NewServer(d *dex.Dex) *grpc.Server {
+	srv := &dexServer{d: d, subscribers: make(map[int]subscriber)}
+	s := grpc.NewServer()
+	dexv1.RegisterDexServiceServer(s, srv)
+	return s
+}
+
+// Handle implements dexv1.DexServiceServer.
+func (s *dexServer) Handle(ctx context.Context, req *dexv1.EventRequest) (*dexv1.EventResponse, error) {
+	pbEv := req.GetEvent()
+	ev := dex.Event{
+		Name:     pbEv.GetName(),
+		Payload:  pbEv.GetPayload(),
+		Identity: pbEv.GetHeaders()["identity"],
+		Headers:  pbEv.GetHeaders(),
+	}
+
+	err := s.d.Handle(ctx, ev)
+	s.broadcast(pbEv)
+
+	if err != nil {
+		return &dexv1.EventResponse{Error: err.Error()}, nil
+	}
+	return &dexv1.EventResponse{}, nil
+}
+
+// Subscribe implements dexv1.DexServiceServer, streaming every event
+// whose name matches req.NameGlob to the caller until the stream's
+// context is done. Events that arrive while the subscriber's 64-event
+// buffer is full are dropped rather than delivered.
+func (s *dexServer) Subscribe(req *dexv1.SubscribeRequest, stream dexv1.DexService_SubscribeServer) error {
+	pattern := req.GetNameGlob()
+	if pattern == "" {
+		pattern = "*"
+	}
+	g, err := glob.Compile(pattern)
+	if err != nil {
+		return err
+	}
+
+	id, ch := s.addSubscriber(g)
+	defer s.removeSubscriber(id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case ev := <-ch:
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *dexServer) addSubscriber(g glob.Glob) (int, chan *dexv1.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID
+	s.nextID++
+	ch := make(chan *dexv1.Event, 64)
+	s.subscribers[id] = subscriber{pattern: g, ch: ch}
+	return id, ch
+}
+
+func (s *dexServer) removeSubscriber(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, id)
+}
+
+func (s *dexServer) broadcast(ev *dexv1.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subscribers {
+		if !sub.pattern.Match(ev.GetName()) {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+			// Slow subscriber; drop rather than block dispatch.
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yethikrishna/levelcode/dex"
+)
+
+const bufSize = 1024 * 1024
+
+func dialer(lis *bufconn.Listener) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+}
+
+func TestHandleOverGRPC(t *testing.T) {
+	d := dex.New()
+
+	var handled int64
+	d.RegisterHandler("count", func(ctx context.Context, ev dex.Event) error {
+		atomic.AddInt64(&handled, 1)
+		return nil
+	})
+	if err := d.AddRoute("ping.*", "count", dex.Public); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+
+	lis := bufconn.Listen(bufSize)
+	srv := NewServer(d)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer(lis)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	defer conn.Close()
+
+	client := NewClient(conn)
+
+	const n = 1000
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Handle(ctx, dex.Event{Name: "ping.one"}); err != nil {
+				t.Errorf("Handle: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt64(&handled); got != n {
+		t.Fatalf("handled %d events, want %d", got, n)
+	}
+}
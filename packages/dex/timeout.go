@@ -0,0 +1,18 @@
+package dex
+
+import (
+	"context"
+	"time"
+)
+
+// TimeoutMiddleware bounds how long a handler may take to process an
+// event, canceling its context once d elapses.
+func TimeoutMiddleware(d time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, ev Event) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, ev)
+		}
+	}
+}